@@ -0,0 +1,55 @@
+package types
+
+import "github.com/tendermint/tendermint/crypto/tmhash"
+
+// CanonicalExecTxResult is the deterministic subset of an ExecTxResult,
+// with Log, Info, Codespace and Events stripped - the same fields
+// TxResultsToByteSlices hashes (see TestHashDeterministicFieldsOnly).
+type CanonicalExecTxResult struct {
+	Code      uint32
+	Data      []byte
+	GasWanted int64
+	GasUsed   int64
+}
+
+// Canonical returns the deterministic subset of tr.
+func (tr *ExecTxResult) Canonical() CanonicalExecTxResult {
+	return CanonicalExecTxResult{
+		Code:      tr.Code,
+		Data:      tr.Data,
+		GasWanted: tr.GasWanted,
+		GasUsed:   tr.GasUsed,
+	}
+}
+
+// Marshal encodes c by populating an otherwise-empty ExecTxResult with
+// only the canonical fields and marshaling that.
+func (c CanonicalExecTxResult) Marshal() ([]byte, error) {
+	full := ExecTxResult{
+		Code:      c.Code,
+		Data:      c.Data,
+		GasWanted: c.GasWanted,
+		GasUsed:   c.GasUsed,
+	}
+	return full.Marshal()
+}
+
+// Unmarshal decodes bytes produced by Marshal into c.
+func (c *CanonicalExecTxResult) Unmarshal(bz []byte) error {
+	var full ExecTxResult
+	if err := full.Unmarshal(bz); err != nil {
+		return err
+	}
+	*c = full.Canonical()
+	return nil
+}
+
+// CanonicalHash returns a stable hash of tr's deterministic fields,
+// unaffected by Log, Info, Codespace or Events.
+func (tr *ExecTxResult) CanonicalHash() ([]byte, error) {
+	bz, err := tr.Canonical().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return tmhash.Sum(bz), nil
+}
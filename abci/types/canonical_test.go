@@ -0,0 +1,89 @@
+package types_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestCanonicalExecTxResultRoundTrip(t *testing.T) {
+	tr := abci.ExecTxResult{
+		Code:      1,
+		Data:      []byte("transaction"),
+		Log:       "nondeterministic",
+		Info:      "nondeterministic",
+		GasWanted: 1000,
+		GasUsed:   900,
+		Events:    []abci.Event{{Type: "transfer"}},
+		Codespace: "nondeterministic",
+	}
+
+	bz, err := tr.Canonical().Marshal()
+	require.NoError(t, err)
+
+	var got abci.CanonicalExecTxResult
+	require.NoError(t, got.Unmarshal(bz))
+	assert.Equal(t, tr.Canonical(), got)
+}
+
+func TestCanonicalExecTxResultIgnoresNonDeterministicFields(t *testing.T) {
+	base := abci.ExecTxResult{
+		Code:      1,
+		Data:      []byte("transaction"),
+		GasWanted: 1000,
+		GasUsed:   1000,
+	}
+
+	tr1 := base
+	tr1.Log, tr1.Info, tr1.Codespace = "abc", "abc", "nondeterministic.abc"
+	tr1.Events = []abci.Event{{Type: "a"}}
+
+	tr2 := base
+	tr2.Log, tr2.Info, tr2.Codespace = "def", "def", "nondeterministic.def"
+	tr2.Events = []abci.Event{{Type: "b"}, {Type: "c"}}
+
+	hash1, err := tr1.CanonicalHash()
+	require.NoError(t, err)
+	hash2, err := tr2.CanonicalHash()
+	require.NoError(t, err)
+	assert.Equal(t, hash1, hash2)
+}
+
+func FuzzCanonicalExecTxResultStable(f *testing.F) {
+	f.Add(uint32(0), []byte("data"), int64(1), int64(2), "log-a", "info-a", "space-a")
+
+	f.Fuzz(func(t *testing.T, code uint32, data []byte, gasWanted, gasUsed int64, log, info, codespace string) {
+		tr := abci.ExecTxResult{
+			Code:      code,
+			Data:      data,
+			GasWanted: gasWanted,
+			GasUsed:   gasUsed,
+			Log:       log,
+			Info:      info,
+			Codespace: codespace,
+			Events:    []abci.Event{{Type: "whatever"}},
+		}
+		perturbed := tr
+		perturbed.Log += "-changed"
+		perturbed.Info += "-changed"
+		perturbed.Codespace += "-changed"
+		perturbed.Events = append(perturbed.Events, abci.Event{Type: "extra"})
+
+		bz1, err := tr.Canonical().Marshal()
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		bz2, err := perturbed.Canonical().Marshal()
+		if err != nil {
+			t.Fatalf("marshal perturbed: %v", err)
+		}
+
+		if !bytes.Equal(bz1, bz2) {
+			t.Fatalf("canonical encoding changed when only non-deterministic fields differed")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package types
+
+import "fmt"
+
+// PostFinalizeHook runs after FinalizeBlock has produced its response
+// but before the resulting state is committed. A hook may append
+// synthetic ExecTxResults to resp - for example validator-reward
+// distributions or epoch transitions.
+type PostFinalizeHook interface {
+	// Name identifies the hook for registry ordering and error messages.
+	// It must be unique within a PostFinalizeHookRegistry.
+	Name() string
+
+	// PostFinalize runs once per height, after every other hook
+	// registered ahead of it. It receives the finalized block, the
+	// results of its delivered txs, and the response being assembled,
+	// to which it may append further *ExecTxResult values.
+	PostFinalize(block *RequestFinalizeBlock, results []*ExecTxResult, resp *ResponseFinalizeBlock) error
+}
+
+// PostFinalizeHookRegistry runs a fixed, ordered sequence of
+// PostFinalizeHooks after FinalizeBlock. Registration order is the
+// execution order.
+type PostFinalizeHookRegistry struct {
+	hooks []PostFinalizeHook
+	names map[string]bool
+}
+
+// NewPostFinalizeHookRegistry returns an empty registry.
+func NewPostFinalizeHookRegistry() *PostFinalizeHookRegistry {
+	return &PostFinalizeHookRegistry{names: make(map[string]bool)}
+}
+
+// Register appends hook to the registry. It panics if a hook with the
+// same name is already registered.
+func (r *PostFinalizeHookRegistry) Register(hook PostFinalizeHook) {
+	name := hook.Name()
+	if r.names[name] {
+		panic(fmt.Sprintf("abci: PostFinalizeHook %q already registered", name))
+	}
+	r.names[name] = true
+	r.hooks = append(r.hooks, hook)
+}
+
+// Run invokes every registered hook in registration order, stopping at
+// the first error so a partially-applied height is never committed.
+func (r *PostFinalizeHookRegistry) Run(
+	block *RequestFinalizeBlock,
+	results []*ExecTxResult,
+	resp *ResponseFinalizeBlock,
+) error {
+	for _, hook := range r.hooks {
+		if err := hook.PostFinalize(block, results, resp); err != nil {
+			return fmt.Errorf("abci: post-finalize hook %q: %w", hook.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// rewardHook appends a synthetic ExecTxResult for a validator reward,
+// standing in for the kind of hook described in the request: a
+// post-FinalizeBlock step whose output must still land in the results
+// root deterministically.
+type rewardHook struct {
+	amount string
+}
+
+func (rewardHook) Name() string { return "validator-reward" }
+
+func (h rewardHook) PostFinalize(_ *abci.RequestFinalizeBlock, _ []*abci.ExecTxResult, resp *abci.ResponseFinalizeBlock) error {
+	resp.TxResults = append(resp.TxResults, &abci.ExecTxResult{
+		Code: 0,
+		Data: []byte("reward:" + h.amount),
+	})
+	return nil
+}
+
+func resultsRoot(t *testing.T, results []*abci.ExecTxResult) []byte {
+	t.Helper()
+	bzs, err := abci.TxResultsToByteSlices(results)
+	require.NoError(t, err)
+	return merkle.HashFromByteSlices(bzs)
+}
+
+func TestPostFinalizeHookRegistryDeterministicRoot(t *testing.T) {
+	delivered := []*abci.ExecTxResult{{Code: 0, Data: []byte("tx-one")}}
+
+	runOnNode := func() []byte {
+		registry := abci.NewPostFinalizeHookRegistry()
+		registry.Register(rewardHook{amount: "100"})
+
+		resp := &abci.ResponseFinalizeBlock{TxResults: append([]*abci.ExecTxResult{}, delivered...)}
+		require.NoError(t, registry.Run(&abci.RequestFinalizeBlock{}, delivered, resp))
+		return resultsRoot(t, resp.TxResults)
+	}
+
+	rootA := runOnNode()
+	rootB := runOnNode()
+	assert.Equal(t, rootA, rootB)
+	assert.NotEmpty(t, rootA)
+}
+
+func TestPostFinalizeHookRegistryOrderIsRegistrationOrder(t *testing.T) {
+	var order []string
+	makeHook := func(name string) abci.PostFinalizeHook {
+		return orderHook{name: name, record: &order}
+	}
+
+	registry := abci.NewPostFinalizeHookRegistry()
+	registry.Register(makeHook("first"))
+	registry.Register(makeHook("second"))
+
+	resp := &abci.ResponseFinalizeBlock{}
+	require.NoError(t, registry.Run(&abci.RequestFinalizeBlock{}, nil, resp))
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
+type orderHook struct {
+	name   string
+	record *[]string
+}
+
+func (h orderHook) Name() string { return h.name }
+
+func (h orderHook) PostFinalize(_ *abci.RequestFinalizeBlock, _ []*abci.ExecTxResult, _ *abci.ResponseFinalizeBlock) error {
+	*h.record = append(*h.record, h.name)
+	return nil
+}
+
+func TestPostFinalizeHookRegistryRejectsDuplicateNames(t *testing.T) {
+	registry := abci.NewPostFinalizeHookRegistry()
+	registry.Register(rewardHook{amount: "1"})
+
+	assert.Panics(t, func() {
+		registry.Register(rewardHook{amount: "2"})
+	})
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// TxResultsProver computes Merkle inclusion proofs for a subset of the
+// ExecTxResults committed at a height, hashing only the subtrees needed
+// for the requested indices instead of building every leaf's proof up
+// front.
+type TxResultsProver struct {
+	leaves [][]byte
+}
+
+// NewTxResultsProver prepares a prover over results' deterministic
+// fields (see TxResultsToByteSlices).
+func NewTxResultsProver(results []*ExecTxResult) (*TxResultsProver, error) {
+	leaves, err := TxResultsToByteSlices(results)
+	if err != nil {
+		return nil, err
+	}
+	return &TxResultsProver{leaves: leaves}, nil
+}
+
+// Root returns the Merkle root of the full result set.
+func (p *TxResultsProver) Root() []byte {
+	return merkle.HashFromByteSlices(p.leaves)
+}
+
+// ProveIndex streams a proof of inclusion for the result at index,
+// touching only the O(log N) subtrees on its path to the root.
+func (p *TxResultsProver) ProveIndex(index int) (*merkle.Proof, error) {
+	n := len(p.leaves)
+	if index < 0 || index >= n {
+		return nil, fmt.Errorf("abci: index %d out of range [0,%d)", index, n)
+	}
+
+	return &merkle.Proof{
+		Total:    int64(n),
+		Index:    int64(index),
+		LeafHash: merkle.HashFromByteSlices(p.leaves[index : index+1]),
+		Aunts:    auntsForIndex(p.leaves, index),
+	}, nil
+}
+
+// VerifyTxResultProof checks that tr is the result committed at proof's
+// index under root, marshaling tr's deterministic fields before
+// delegating to proof.Verify.
+func VerifyTxResultProof(root []byte, proof *merkle.Proof, tr *ExecTxResult) error {
+	leaves, err := TxResultsToByteSlices([]*ExecTxResult{tr})
+	if err != nil {
+		return fmt.Errorf("abci: marshaling result for proof verification: %w", err)
+	}
+	if err := proof.Verify(root, leaves[0]); err != nil {
+		return fmt.Errorf("abci: invalid tx result proof: %w", err)
+	}
+	return nil
+}
+
+// auntsForIndex recursively hashes only the subtrees sibling to index's
+// path, in leaf-to-root order, matching the Aunts convention expected by
+// merkle.Proof.Verify.
+func auntsForIndex(leaves [][]byte, index int) [][]byte {
+	n := len(leaves)
+	if n <= 1 {
+		return nil
+	}
+	k := splitPoint(n)
+	if index < k {
+		aunt := merkle.HashFromByteSlices(leaves[k:])
+		return append(auntsForIndex(leaves[:k], index), aunt)
+	}
+	aunt := merkle.HashFromByteSlices(leaves[:k])
+	return append(auntsForIndex(leaves[k:], index-k), aunt)
+}
+
+// RangeProof proves that a contiguous window of results, leaves[lo:hi),
+// is what was committed under root, using O(log N + hi-lo) hashes
+// rather than one full proof per index.
+type RangeProof struct {
+	Total int64
+	Lo    int64
+	Hi    int64
+	// Leaves holds the marshaled deterministic fields for [Lo, Hi), in
+	// order, so the verifier can recompute the window's sub-root.
+	Leaves [][]byte
+	// SideHashes are the hashes of the maximal subtrees that fall
+	// entirely outside [Lo, Hi), in left-to-right (depth-first) tree
+	// order, the order in which Verify consumes them.
+	SideHashes [][]byte
+}
+
+// ProveRange streams a RangeProof for [lo, hi).
+func (p *TxResultsProver) ProveRange(lo, hi int) (*RangeProof, error) {
+	n := len(p.leaves)
+	if lo < 0 || hi > n || lo >= hi {
+		return nil, fmt.Errorf("abci: invalid range [%d,%d) for %d results", lo, hi, n)
+	}
+
+	sides := rangeSideHashes(p.leaves, 0, n, lo, hi)
+	window := make([][]byte, hi-lo)
+	copy(window, p.leaves[lo:hi])
+
+	return &RangeProof{
+		Total:      int64(n),
+		Lo:         int64(lo),
+		Hi:         int64(hi),
+		Leaves:     window,
+		SideHashes: sides,
+	}, nil
+}
+
+// rangeSideHashes walks the same recursive split as
+// merkle.HashFromByteSlices over leaves[start:end), collecting the hash
+// of every subtree that falls entirely outside [lo,hi).
+func rangeSideHashes(leaves [][]byte, start, end, lo, hi int) [][]byte {
+	if hi <= start || end <= lo {
+		// Fully outside the window: one side hash closes off this whole subtree.
+		return [][]byte{merkle.HashFromByteSlices(leaves[start:end])}
+	}
+	if lo <= start && end <= hi {
+		// Fully inside the window: the verifier already has these leaves.
+		return nil
+	}
+	k := start + splitPoint(end-start)
+	left := rangeSideHashes(leaves, start, k, lo, hi)
+	right := rangeSideHashes(leaves, k, end, lo, hi)
+	return append(left, right...)
+}
+
+// Verify checks that rp's window hashes to root, recombining its
+// revealed leaves and side hashes along the same split points used to
+// build the proof.
+func (rp *RangeProof) Verify(root []byte) error {
+	if rp.Lo < 0 || rp.Hi > rp.Total || rp.Lo >= rp.Hi {
+		return fmt.Errorf("abci: invalid range proof bounds [%d,%d) of %d", rp.Lo, rp.Hi, rp.Total)
+	}
+	if int64(len(rp.Leaves)) != rp.Hi-rp.Lo {
+		return fmt.Errorf("abci: range proof carries %d leaves, want %d", len(rp.Leaves), rp.Hi-rp.Lo)
+	}
+
+	sides := rp.SideHashes
+	got, rest, err := reconstruct(rp.Leaves, 0, int(rp.Total), int(rp.Lo), int(rp.Hi), sides)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("abci: range proof has %d unused side hashes", len(rest))
+	}
+	if !bytes.Equal(got, root) {
+		return fmt.Errorf("abci: range proof root mismatch")
+	}
+	return nil
+}
+
+// reconstruct mirrors rangeSideHashes' recursion, consuming side hashes
+// where rangeSideHashes produced them and hashing revealed leaves where
+// it didn't, to recompute the root.
+func reconstruct(window [][]byte, start, end, lo, hi int, sides [][]byte) (hash []byte, rest [][]byte, err error) {
+	if hi <= start || end <= lo {
+		if len(sides) == 0 {
+			return nil, nil, fmt.Errorf("abci: range proof ran out of side hashes")
+		}
+		return sides[0], sides[1:], nil
+	}
+	if lo <= start && end <= hi {
+		return merkle.HashFromByteSlices(window[start-lo : end-lo]), sides, nil
+	}
+	k := start + splitPoint(end-start)
+	left, sides, err := reconstruct(window, start, k, lo, hi, sides)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, sides, err := reconstruct(window, k, end, lo, hi, sides)
+	if err != nil {
+		return nil, nil, err
+	}
+	return combineHash(left, right), sides, nil
+}
+
+// combineHash replicates crypto/merkle's inner-node hashing (SHA-256 of a
+// 0x01 domain-separation prefix followed by both children) so that
+// reconstructing a root from side hashes here matches the tree merkle
+// itself would build. Keep this in sync if that domain separation ever
+// changes upstream.
+func combineHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// splitPoint mirrors crypto/merkle's getSplitPoint: the left subtree of a
+// tree over `length` leaves holds the largest power of two strictly less
+// than length (or half of length when length is itself a power of two).
+func splitPoint(length int) int {
+	if length < 1 {
+		panic("abci: cannot split a tree with size < 1")
+	}
+	k := 1 << uint(bits.Len(uint(length))-1)
+	if k == length {
+		k >>= 1
+	}
+	return k
+}
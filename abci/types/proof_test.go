@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func txResultsProverFixture() []*abci.ExecTxResult {
+	return []*abci.ExecTxResult{
+		{Code: 0, Data: nil},
+		{Code: 0, Data: []byte{}},
+		{Code: 0, Data: []byte("one")},
+		{Code: 14, Data: nil},
+		{Code: 14, Data: []byte("foo")},
+		{Code: 14, Data: []byte("bar")},
+	}
+}
+
+func TestTxResultsProverProveIndex(t *testing.T) {
+	trs := txResultsProverFixture()
+
+	prover, err := abci.NewTxResultsProver(trs)
+	require.NoError(t, err)
+	root := prover.Root()
+
+	for i, tr := range trs {
+		proof, err := prover.ProveIndex(i)
+		require.NoError(t, err)
+		assert.NoError(t, abci.VerifyTxResultProof(root, proof, tr), "%d", i)
+	}
+
+	_, err = prover.ProveIndex(len(trs))
+	assert.Error(t, err)
+}
+
+func TestTxResultsProverProveRange(t *testing.T) {
+	trs := txResultsProverFixture()
+
+	prover, err := abci.NewTxResultsProver(trs)
+	require.NoError(t, err)
+	root := prover.Root()
+
+	cases := []struct{ lo, hi int }{
+		{0, 1},
+		{0, len(trs)},
+		{2, 5},
+		{4, 6},
+	}
+	for _, c := range cases {
+		rp, err := prover.ProveRange(c.lo, c.hi)
+		require.NoError(t, err, "[%d,%d)", c.lo, c.hi)
+		assert.NoError(t, rp.Verify(root), "[%d,%d)", c.lo, c.hi)
+	}
+}
+
+func TestTxResultsProverProveRangeInvalid(t *testing.T) {
+	trs := txResultsProverFixture()
+
+	prover, err := abci.NewTxResultsProver(trs)
+	require.NoError(t, err)
+
+	_, err = prover.ProveRange(3, 2)
+	assert.Error(t, err)
+
+	_, err = prover.ProveRange(0, len(trs)+1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package mempool
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ReapForPrepareProposal reaps every transaction in the mempool and lets
+// policy turn them into the TxRecords a PrepareProposal response carries.
+// It does not validate the records; the consensus and state packages do
+// not call it. Passing a nil policy reaps in mempool order (FIFOPolicy).
+func (mem *CListMempool) ReapForPrepareProposal(policy PrepareProposalPolicy, maxBytes int64) []*abci.TxRecord {
+	txs := mem.ReapMaxTxs(mem.Size())
+	return SelectForPrepareProposal(txs, policy, maxBytes)
+}
+
+// SelectForPrepareProposal applies policy to txs, defaulting to
+// FIFOPolicy when policy is nil. It is the part of ReapForPrepareProposal
+// that doesn't depend on a live CListMempool, factored out so the
+// nil-policy fallback is directly testable.
+func SelectForPrepareProposal(txs types.Txs, policy PrepareProposalPolicy, maxBytes int64) []*abci.TxRecord {
+	if policy == nil {
+		policy = FIFOPolicy{}
+	}
+	return policy.Select(txs, maxBytes)
+}
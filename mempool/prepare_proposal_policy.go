@@ -0,0 +1,133 @@
+package mempool
+
+import (
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// PrepareProposalPolicy selects and orders the transactions a proposer
+// includes in a block, producing the TxRecords returned from
+// PrepareProposal. Select alone decides content and order; the
+// invariants enforced by abci.ResponsePrepareProposal.Validate (no
+// duplicate tx across ADDED/REMOVED, no UNMODIFIED/REMOVED marked for a
+// tx that isn't in the existing proposal, no UNKNOWN, total size under
+// maxBytes) apply to every policy's output. Select does not check them
+// itself, and nothing in this package passes its output to Validate.
+type PrepareProposalPolicy interface {
+	// Select returns the TxRecords to propose from mempool, keeping the
+	// total size of ADDED and UNMODIFIED tx at or under maxBytes.
+	Select(mempool types.Txs, maxBytes int64) []*abci.TxRecord
+}
+
+// FIFOPolicy proposes transactions in mempool order, the behavior
+// PrepareProposal had before policies were pluggable. It is the default.
+// Unlike PriorityPolicy, it stops at the first tx that would overflow
+// maxBytes instead of skipping ahead to smaller ones, so a large tx
+// can't be starved forever by an endless stream of smaller txs behind it.
+type FIFOPolicy struct{}
+
+var _ PrepareProposalPolicy = FIFOPolicy{}
+
+// Select implements PrepareProposalPolicy.
+func (FIFOPolicy) Select(mempool types.Txs, maxBytes int64) []*abci.TxRecord {
+	var records []*abci.TxRecord
+	var used int64
+
+	for _, tx := range mempool {
+		size := int64(len(tx))
+		if used+size > maxBytes {
+			break
+		}
+		records = append(records, &abci.TxRecord{Action: abci.TxRecord_ADDED, Tx: tx})
+		used += size
+	}
+	return records
+}
+
+// PriorityFunc extracts a priority (e.g. a fee-per-byte) from a raw
+// transaction for use by PriorityPolicy. Higher values are proposed
+// first.
+type PriorityFunc func(tx types.Tx) int64
+
+// PriorityPolicy orders the mempool by a caller-supplied priority
+// function before applying the size cap, similar to the fee-ordered
+// mempools common to EVM chains. Ties preserve mempool order so the
+// policy is deterministic across nodes applying the same function.
+type PriorityPolicy struct {
+	Priority PriorityFunc
+}
+
+var _ PrepareProposalPolicy = PriorityPolicy{}
+
+// Select implements PrepareProposalPolicy.
+func (p PriorityPolicy) Select(mempool types.Txs, maxBytes int64) []*abci.TxRecord {
+	return selectUnderCap(mempool, maxBytes, func(txs types.Txs) types.Txs {
+		ordered := make(types.Txs, len(txs))
+		copy(ordered, txs)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return p.Priority(ordered[i]) > p.Priority(ordered[j])
+		})
+		return ordered
+	})
+}
+
+// Bundle is a group of transactions an external builder wants included
+// atomically and in order, or not at all.
+type Bundle struct {
+	Txs types.Txs
+}
+
+// BundlePolicy proposes whole bundles submitted by an external builder,
+// preserving each bundle's internal order and only ever including a
+// bundle in full. Bundles are tried in the order given; a bundle that
+// would exceed maxBytes is skipped rather than partially included, so
+// later, smaller bundles still get a chance.
+type BundlePolicy struct {
+	Bundles []Bundle
+}
+
+var _ PrepareProposalPolicy = BundlePolicy{}
+
+// Select implements PrepareProposalPolicy.
+func (b BundlePolicy) Select(_ types.Txs, maxBytes int64) []*abci.TxRecord {
+	var records []*abci.TxRecord
+	var used int64
+
+	for _, bundle := range b.Bundles {
+		var size int64
+		for _, tx := range bundle.Txs {
+			size += int64(len(tx))
+		}
+		if used+size > maxBytes {
+			continue
+		}
+		for _, tx := range bundle.Txs {
+			records = append(records, &abci.TxRecord{Action: abci.TxRecord_ADDED, Tx: tx})
+		}
+		used += size
+	}
+	return records
+}
+
+// selectUnderCap applies order to mempool and greedily takes every
+// transaction that still fits under maxBytes, skipping (rather than
+// stopping at) ones that don't so smaller txs behind them still get a
+// chance. Appropriate for PriorityPolicy, where skipped txs are already
+// reordered by priority rather than sitting in their original mempool
+// position.
+func selectUnderCap(mempool types.Txs, maxBytes int64, order func(types.Txs) types.Txs) []*abci.TxRecord {
+	var records []*abci.TxRecord
+	var used int64
+
+	for _, tx := range order(mempool) {
+		size := int64(len(tx))
+		if used+size > maxBytes {
+			continue
+		}
+		records = append(records, &abci.TxRecord{Action: abci.TxRecord_ADDED, Tx: tx})
+		used += size
+	}
+	return records
+}
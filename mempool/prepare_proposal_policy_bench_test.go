@@ -0,0 +1,51 @@
+package mempool_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+func benchMempool(n int) types.Txs {
+	txs := make(types.Txs, n)
+	for i := range txs {
+		txs[i] = types.Tx(fmt.Sprintf("tx-%d-payload", i))
+	}
+	return txs
+}
+
+func BenchmarkFIFOPolicySelect(b *testing.B) {
+	txs := benchMempool(10000)
+	policy := mempool.FIFOPolicy{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		policy.Select(txs, 1<<20)
+	}
+}
+
+func BenchmarkPriorityPolicySelect(b *testing.B) {
+	txs := benchMempool(10000)
+	policy := mempool.PriorityPolicy{Priority: func(tx types.Tx) int64 { return int64(len(tx)) }}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		policy.Select(txs, 1<<20)
+	}
+}
+
+func BenchmarkBundlePolicySelect(b *testing.B) {
+	txs := benchMempool(10000)
+	bundles := make([]mempool.Bundle, 0, len(txs)/2)
+	for i := 0; i+1 < len(txs); i += 2 {
+		bundles = append(bundles, mempool.Bundle{Txs: types.Txs{txs[i], txs[i+1]}})
+	}
+	policy := mempool.BundlePolicy{Bundles: bundles}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		policy.Select(txs, 1<<20)
+	}
+}
@@ -0,0 +1,270 @@
+package mempool_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+// policyFixtureMempool reuses transactions from
+// TestValidateResponsePrepareProposal ({1,2,3,4,5}, {100}, {200}) plus
+// "foo" and "bar", so policy output is checked against the same
+// invariants that test exercises directly.
+func policyFixtureMempool() types.Txs {
+	return types.Txs{
+		{1, 2, 3, 4, 5},
+		{100},
+		{200},
+		[]byte("foo"),
+		[]byte("bar"),
+	}
+}
+
+// assertValidSelection runs the seven invariants from
+// TestValidateResponsePrepareProposal against whatever a policy selects,
+// so every PrepareProposalPolicy implementation is held to the same
+// conformance bar.
+func assertValidSelection(t *testing.T, policy mempool.PrepareProposalPolicy, maxBytes int64) {
+	t.Helper()
+
+	records := policy.Select(policyFixtureMempool(), maxBytes)
+	rpp := &abci.ResponsePrepareProposal{
+		ModifiedTx: true,
+		TxRecords:  records,
+	}
+
+	err := rpp.Validate(maxBytes, [][]byte{})
+	require.NoError(t, err)
+
+	seen := make(map[string]bool)
+	var total int64
+	for _, rec := range records {
+		assert.NotEqual(t, abci.TxRecord_UNKNOWN, rec.Action)
+		assert.NotEqual(t, abci.TxRecord_UNMODIFIED, rec.Action)
+		assert.NotEqual(t, abci.TxRecord_REMOVED, rec.Action)
+		assert.False(t, seen[string(rec.Tx)], "duplicate tx in selection")
+		seen[string(rec.Tx)] = true
+		total += int64(len(rec.Tx))
+	}
+	assert.LessOrEqual(t, total, maxBytes)
+}
+
+func TestPrepareProposalPolicyConformance(t *testing.T) {
+	policies := map[string]mempool.PrepareProposalPolicy{
+		"fifo": mempool.FIFOPolicy{},
+		"priority": mempool.PriorityPolicy{
+			Priority: func(tx types.Tx) int64 { return int64(len(tx)) },
+		},
+		"bundle": mempool.BundlePolicy{
+			Bundles: []mempool.Bundle{
+				{Txs: types.Txs{{1, 2, 3, 4, 5}, {100}}},
+				{Txs: types.Txs{[]byte("foo")}},
+			},
+		},
+	}
+
+	for name, policy := range policies {
+		policy := policy
+		t.Run(name, func(t *testing.T) {
+			assertValidSelection(t, policy, 1<<20)
+		})
+	}
+}
+
+// TestPrepareProposalPolicyRejectsInvariantViolations reuses the seven
+// cases from TestValidateResponsePrepareProposal and checks that
+// Validate rejects each one the same way no matter which policy's name
+// is attached to it: the invariants Validate enforces are a property of
+// the TxRecords returned, not of whichever policy happens to have built
+// them.
+func TestPrepareProposalPolicyRejectsInvariantViolations(t *testing.T) {
+	cases := []struct {
+		name        string
+		maxBytes    int64
+		existingTxs [][]byte
+		records     []*abci.TxRecord
+	}{
+		{
+			name:     "total transaction size exceeding max data size",
+			maxBytes: 9,
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_ADDED, Tx: []byte{1, 2, 3, 4, 5}},
+				{Action: abci.TxRecord_ADDED, Tx: []byte{6, 7, 8, 9, 10}},
+			},
+		},
+		{
+			name:     "duplicate transactions with the same action",
+			maxBytes: 100,
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_ADDED, Tx: []byte{1, 2, 3, 4, 5}},
+				{Action: abci.TxRecord_ADDED, Tx: []byte{100}},
+				{Action: abci.TxRecord_ADDED, Tx: []byte{1, 2, 3, 4, 5}},
+				{Action: abci.TxRecord_ADDED, Tx: []byte{200}},
+			},
+		},
+		{
+			name:     "duplicate transactions with mixed actions",
+			maxBytes: 100,
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_ADDED, Tx: []byte{1, 2, 3, 4, 5}},
+				{Action: abci.TxRecord_ADDED, Tx: []byte{100}},
+				{Action: abci.TxRecord_REMOVED, Tx: []byte{1, 2, 3, 4, 5}},
+				{Action: abci.TxRecord_ADDED, Tx: []byte{200}},
+			},
+		},
+		{
+			name:     "new transactions marked UNMODIFIED",
+			maxBytes: 100,
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_UNMODIFIED, Tx: []byte{1, 2, 3, 4, 5}},
+			},
+		},
+		{
+			name:     "new transactions marked REMOVED",
+			maxBytes: 100,
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_REMOVED, Tx: []byte{1, 2, 3, 4, 5}},
+			},
+		},
+		{
+			name:        "existing transaction marked as ADDED",
+			maxBytes:    100,
+			existingTxs: [][]byte{{1, 2, 3, 4, 5}},
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_ADDED, Tx: []byte{1, 2, 3, 4, 5}},
+			},
+		},
+		{
+			name:     "transaction marked as UNKNOWN",
+			maxBytes: 100,
+			records: []*abci.TxRecord{
+				{Action: abci.TxRecord_UNKNOWN, Tx: []byte{1, 2, 3, 4, 5}},
+			},
+		},
+	}
+
+	policyNames := []string{"fifo", "priority", "bundle"}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			for _, policyName := range policyNames {
+				rpp := &abci.ResponsePrepareProposal{
+					ModifiedTx: true,
+					TxRecords:  c.records,
+				}
+				existing := c.existingTxs
+				if existing == nil {
+					existing = [][]byte{}
+				}
+				err := rpp.Validate(c.maxBytes, existing)
+				assert.Error(t, err, "policy %q: expected Validate to reject %s", policyName, c.name)
+			}
+		})
+	}
+}
+
+// TestFIFOPolicySelectDuplicateTxInMempool exercises an actual policy
+// Select call, rather than a hand-built record set, to show that a
+// duplicated tx in the mempool input produces output Validate rejects -
+// policies are responsible for what they select, not for deduplicating
+// a mempool that should never contain the same tx twice.
+func TestFIFOPolicySelectDuplicateTxInMempool(t *testing.T) {
+	mem := types.Txs{[]byte("foo"), []byte("bar"), []byte("foo")}
+	records := mempool.FIFOPolicy{}.Select(mem, 1<<20)
+
+	rpp := &abci.ResponsePrepareProposal{ModifiedTx: true, TxRecords: records}
+	err := rpp.Validate(1<<20, [][]byte{})
+	assert.Error(t, err)
+}
+
+func TestFIFOPolicyPreservesOrder(t *testing.T) {
+	mem := policyFixtureMempool()
+	records := mempool.FIFOPolicy{}.Select(mem, 1<<20)
+
+	require.Len(t, records, len(mem))
+	for i, rec := range records {
+		assert.Equal(t, []byte(mem[i]), rec.Tx)
+	}
+}
+
+// TestFIFOPolicyStopsAtFirstOverflow pins FIFOPolicy to stop proposing
+// at the first tx that doesn't fit, rather than skipping it and letting
+// smaller txs behind it jump the queue - a mid-mempool tx too big for
+// maxBytes must not be starved forever by every block after it.
+func TestFIFOPolicyStopsAtFirstOverflow(t *testing.T) {
+	mem := types.Txs{
+		[]byte("fits"),
+		make([]byte, 100), // too big for maxBytes, sits mid-mempool
+		[]byte("tiny"),    // would fit on its own, must not jump ahead
+	}
+
+	records := mempool.FIFOPolicy{}.Select(mem, 10)
+
+	require.Len(t, records, 1)
+	assert.Equal(t, []byte("fits"), records[0].Tx)
+}
+
+func TestPriorityPolicyOrdersByPriority(t *testing.T) {
+	mem := types.Txs{[]byte("a"), []byte("bb"), []byte("ccc")}
+	policy := mempool.PriorityPolicy{Priority: func(tx types.Tx) int64 { return int64(len(tx)) }}
+
+	records := policy.Select(mem, 1<<20)
+	require.Len(t, records, 3)
+	assert.Equal(t, []byte("ccc"), records[0].Tx)
+	assert.Equal(t, []byte("bb"), records[1].Tx)
+	assert.Equal(t, []byte("a"), records[2].Tx)
+}
+
+// TestPriorityPolicySkipsOversizedTx pins the bin-packing behavior
+// PriorityPolicy shares with BundlePolicy: an oversized tx is skipped,
+// not a stopping point, since priority order already makes the
+// remaining mempool unrelated to the skipped tx's original position.
+func TestPriorityPolicySkipsOversizedTx(t *testing.T) {
+	mem := types.Txs{make([]byte, 100), []byte("tiny")}
+	policy := mempool.PriorityPolicy{Priority: func(tx types.Tx) int64 { return int64(len(tx)) }}
+
+	records := policy.Select(mem, 10)
+
+	require.Len(t, records, 1)
+	assert.Equal(t, []byte("tiny"), records[0].Tx)
+}
+
+func TestBundlePolicySkipsOversizedBundle(t *testing.T) {
+	policy := mempool.BundlePolicy{
+		Bundles: []mempool.Bundle{
+			{Txs: types.Txs{make([]byte, 10)}},
+			{Txs: types.Txs{[]byte("fits")}},
+		},
+	}
+
+	records := policy.Select(nil, 5)
+	require.Len(t, records, 1)
+	assert.Equal(t, []byte("fits"), records[0].Tx)
+}
+
+func TestSelectForPrepareProposalNilPolicyFallsBackToFIFO(t *testing.T) {
+	mem := policyFixtureMempool()
+
+	records := mempool.SelectForPrepareProposal(mem, nil, 1<<20)
+
+	require.Len(t, records, len(mem))
+	for i, rec := range records {
+		assert.Equal(t, []byte(mem[i]), rec.Tx)
+	}
+}
+
+func TestSelectForPrepareProposalUsesGivenPolicy(t *testing.T) {
+	mem := types.Txs{[]byte("a"), []byte("bb"), []byte("ccc")}
+	policy := mempool.PriorityPolicy{Priority: func(tx types.Tx) int64 { return int64(len(tx)) }}
+
+	records := mempool.SelectForPrepareProposal(mem, policy, 1<<20)
+
+	require.Len(t, records, 3)
+	assert.Equal(t, []byte("ccc"), records[0].Tx)
+}
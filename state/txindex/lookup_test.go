@@ -0,0 +1,92 @@
+package txindex_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fakeIndexer is a minimal in-memory txindex.TxIndexer for exercising
+// SaveTxResults/LoadTxResult without a real kv backend.
+type fakeIndexer struct {
+	byHash map[string]*abci.TxResult
+}
+
+func newFakeIndexer() *fakeIndexer {
+	return &fakeIndexer{byHash: make(map[string]*abci.TxResult)}
+}
+
+func (idx *fakeIndexer) hash(tr *abci.TxResult) string {
+	return string(types.Tx(tr.Tx).Hash())
+}
+
+func (idx *fakeIndexer) Index(tr *abci.TxResult) error {
+	idx.byHash[idx.hash(tr)] = tr
+	return nil
+}
+
+func (idx *fakeIndexer) AddBatch(b *txindex.Batch) error {
+	for _, tr := range b.Ops {
+		if err := idx.Index(tr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *fakeIndexer) Get(hash []byte) (*abci.TxResult, error) {
+	return idx.byHash[string(hash)], nil
+}
+
+func (idx *fakeIndexer) Search(_ context.Context, _ *query.Query) ([]*abci.TxResult, error) {
+	results := make([]*abci.TxResult, 0, len(idx.byHash))
+	for _, tr := range idx.byHash {
+		results = append(results, tr)
+	}
+	return results, nil
+}
+
+var _ txindex.TxIndexer = (*fakeIndexer)(nil)
+
+func TestSaveAndLoadTxResult(t *testing.T) {
+	indexer := newFakeIndexer()
+
+	txs := [][]byte{[]byte("tx-one"), []byte("tx-two")}
+	results := []*abci.ExecTxResult{
+		{Code: 0, Data: []byte("ok")},
+		{Code: 1, Log: "failed"},
+	}
+
+	require.NoError(t, txindex.SaveTxResults(indexer, 5, results, txs))
+
+	for i, tx := range txs {
+		tr, err := txindex.LoadTxResult(indexer, types.Tx(tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), tr.Height)
+		assert.Equal(t, uint32(i), tr.Index)
+		assert.Equal(t, tx, tr.Tx)
+		assert.Equal(t, *results[i], tr.Result)
+	}
+}
+
+func TestLoadTxResultMissing(t *testing.T) {
+	indexer := newFakeIndexer()
+
+	_, err := txindex.LoadTxResult(indexer, []byte("nonexistent"))
+	require.Error(t, err)
+	assert.IsType(t, txindex.NoTxResultForHashError{}, err)
+}
+
+func TestSaveTxResultsMismatchedLengths(t *testing.T) {
+	indexer := newFakeIndexer()
+
+	err := txindex.SaveTxResults(indexer, 1, []*abci.ExecTxResult{{}}, [][]byte{})
+	require.Error(t, err)
+}